@@ -0,0 +1,268 @@
+/*
+ * Minio Cloud Storage, (C) 2016, 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/v10"
+	"github.com/minio/minio/cmd/config/v11"
+	"github.com/minio/minio/cmd/config/v2"
+	"github.com/minio/minio/cmd/config/v3"
+	"github.com/minio/minio/cmd/config/v4"
+	"github.com/minio/minio/cmd/config/v5"
+	"github.com/minio/minio/cmd/config/v6"
+	"github.com/minio/minio/cmd/config/v7"
+	"github.com/minio/minio/cmd/config/v8"
+	"github.com/minio/minio/cmd/config/v9"
+)
+
+// versionTag is the only field migrateConfig needs to inspect before
+// deciding whether the on-disk file is already current.
+type versionTag struct {
+	Version string `json:"version"`
+}
+
+// configV1 was the very first config file format, tagged version
+// "1": a bare accessKeyId/secretAccessKey pair stored in
+// fsUsers.json next to the main config file.
+type configV1 struct {
+	Version         string `json:"version"`
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+}
+
+// getConfigFileV1 returns the legacy v1 config path, fsUsers.json,
+// which lived alongside the main config file.
+func getConfigFileV1() string {
+	return filepath.Join(filepath.Dir(getConfigFile()), "fsUsers.json")
+}
+
+// migrateV1ToV2 purges the legacy fsUsers.json file, if present,
+// carrying its credentials forward into a v2 config.json so the
+// version-package chain in config.Parse can take over from there.
+func migrateV1ToV2() error {
+	v1File := getConfigFileV1()
+	raw, err := ioutil.ReadFile(v1File)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var v1Cfg configV1
+	if err = json.Unmarshal(raw, &v1Cfg); err != nil {
+		return err
+	}
+
+	cfg := v2.Config{Version: "2"}
+	cfg.Credential.AccessKey = v1Cfg.AccessKeyID
+	cfg.Credential.SecretKey = v1Cfg.SecretAccessKey
+	cfg.Credential.Region = "us-east-1"
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(getConfigFile(), data, 0644); err != nil {
+		return err
+	}
+	return os.Remove(v1File)
+}
+
+// migrateConfig migrates the on-disk config file, if any, to the
+// latest version understood by this server. It first purges the
+// legacy v1 fsUsers.json file if one is found, then, for
+// everything from v2 onward, hands the raw file to config.Parse,
+// which does the version sniffing and chains the version packages'
+// TranslateFromVPrev calls for us. Any fields dropped along the way
+// (e.g. the v2 mongoLogger/syslogLogger backends) are logged as
+// warnings rather than silently lost. The translated config is
+// written back out whenever the file wasn't already at
+// globalMinioConfigVersion; comparing against the tag read off the
+// original bytes, not cfg.Version (which config.Parse always sets to
+// the latest version), is what makes that comparison meaningful.
+func migrateConfig() error {
+	if err := migrateV1ToV2(); err != nil {
+		return err
+	}
+
+	configFile := getConfigFile()
+	raw, err := ioutil.ReadFile(configFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var tag versionTag
+	if err = json.Unmarshal(raw, &tag); err != nil {
+		return err
+	}
+
+	cfg, rep, err := config.Parse(raw)
+	if err != nil {
+		return err
+	}
+	for _, msg := range rep.Messages() {
+		errorIf(nil, msg)
+	}
+
+	// Wire the operator's chosen naming policy in now that we have a
+	// parsed, up-to-date config - this is the same config initConfig
+	// will load into serverConfig right after migrateConfig runs.
+	setNamingPolicy(namingPolicyFromName(cfg.NamingPolicy))
+
+	if tag.Version == globalMinioConfigVersion {
+		return nil
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(configFile, data, 0644)
+}
+
+// migrateV2ToV3 migrates version 2 config to version 3.
+func migrateV2ToV3() error {
+	return migrateConfigStep(func(raw []byte) (interface{}, error) {
+		prev, err := v2.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v3.TranslateFromV2(prev, nil), nil
+	})
+}
+
+// migrateV3ToV4 migrates version 3 config to version 4.
+func migrateV3ToV4() error {
+	return migrateConfigStep(func(raw []byte) (interface{}, error) {
+		prev, err := v3.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v4.TranslateFromV3(prev, nil), nil
+	})
+}
+
+// migrateV4ToV5 migrates version 4 config to version 5.
+func migrateV4ToV5() error {
+	return migrateConfigStep(func(raw []byte) (interface{}, error) {
+		prev, err := v4.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v5.TranslateFromV4(prev, nil), nil
+	})
+}
+
+// migrateV5ToV6 migrates version 5 config to version 6.
+func migrateV5ToV6() error {
+	return migrateConfigStep(func(raw []byte) (interface{}, error) {
+		prev, err := v5.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v6.TranslateFromV5(prev, nil), nil
+	})
+}
+
+// migrateV6ToV7 migrates version 6 config to version 7.
+func migrateV6ToV7() error {
+	return migrateConfigStep(func(raw []byte) (interface{}, error) {
+		prev, err := v6.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v7.TranslateFromV6(prev, nil), nil
+	})
+}
+
+// migrateV7ToV8 migrates version 7 config to version 8.
+func migrateV7ToV8() error {
+	return migrateConfigStep(func(raw []byte) (interface{}, error) {
+		prev, err := v7.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v8.TranslateFromV7(prev, nil), nil
+	})
+}
+
+// migrateV8ToV9 migrates version 8 config to version 9.
+func migrateV8ToV9() error {
+	return migrateConfigStep(func(raw []byte) (interface{}, error) {
+		prev, err := v8.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v9.TranslateFromV8(prev, nil), nil
+	})
+}
+
+// migrateV9ToV10 migrates version 9 config to version 10.
+func migrateV9ToV10() error {
+	return migrateConfigStep(func(raw []byte) (interface{}, error) {
+		prev, err := v9.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v10.TranslateFromV9(prev, nil), nil
+	})
+}
+
+// migrateV10ToV11 migrates version 10 config to version 11.
+func migrateV10ToV11() error {
+	return migrateConfigStep(func(raw []byte) (interface{}, error) {
+		prev, err := v10.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v11.TranslateFromV10(prev, nil), nil
+	})
+}
+
+// migrateConfigStep reads the on-disk config, hands its raw bytes to
+// translate, and writes the translated result back out. A missing
+// config file is not an error - there is nothing to migrate yet; a
+// config file that fails to parse is, since it means the file is
+// corrupted rather than simply old.
+func migrateConfigStep(translate func(raw []byte) (interface{}, error)) error {
+	configFile := getConfigFile()
+	raw, err := ioutil.ReadFile(configFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	next, err := translate(raw)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(next)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(configFile, data, 0644)
+}
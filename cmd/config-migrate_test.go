@@ -19,7 +19,10 @@ package cmd
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/minio/minio/cmd/config"
 )
 
 // Test if config v1 is purged
@@ -207,3 +210,47 @@ func TestServerConfigMigrateFaultyConfig(t *testing.T) {
 		t.Fatal("migrateConfigV10ToV11() should fail with a corrupted json")
 	}
 }
+
+// Test that config.Parse can load a v2 config directly into the
+// latest version in one call, and that the fields it drops along the
+// way (mongoLogger and syslogLogger, removed in v3) show up as
+// warnings instead of disappearing silently.
+func TestConfigParseAnyVersion(t *testing.T) {
+	accessKey := "accessfoo"
+	secretKey := "secretfoo"
+	configJSON := "{ \"version\":\"2\", \"credentials\": {\"accessKeyId\":\"" + accessKey + "\", \"secretAccessKey\":\"" + secretKey + "\", \"region\":\"us-east-1\"}, \"mongoLogger\":{\"addr\":\"127.0.0.1:3543\", \"db\":\"foodb\", \"collection\":\"foo\"}, \"syslogLogger\":{\"network\":\"127.0.0.1:543\", \"addr\":\"addr\"}, \"fileLogger\":{\"filename\":\"log.out\"}}"
+
+	cfg, rep, err := config.Parse([]byte(configJSON))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Version != globalMinioConfigVersion {
+		t.Fatalf("Expected version %s, found: %v", globalMinioConfigVersion, cfg.Version)
+	}
+	if cfg.Credential.AccessKey != accessKey {
+		t.Fatalf("Access key lost during migration, expected: %v, found: %v", accessKey, cfg.Credential.AccessKey)
+	}
+	if cfg.Credential.SecretKey != secretKey {
+		t.Fatalf("Secret key lost during migration, expected: %v, found: %v", secretKey, cfg.Credential.SecretKey)
+	}
+
+	if !rep.HasWarnings() {
+		t.Fatal("Expected warnings about dropped mongoLogger/syslogLogger config, found none")
+	}
+	var sawMongo, sawSyslog bool
+	for _, msg := range rep.Messages() {
+		if strings.Contains(msg, "mongoLogger") {
+			sawMongo = true
+		}
+		if strings.Contains(msg, "syslogLogger") {
+			sawSyslog = true
+		}
+	}
+	if !sawMongo {
+		t.Fatal("Expected a warning about the dropped mongoLogger config")
+	}
+	if !sawSyslog {
+		t.Fatal("Expected a warning about the dropped syslogLogger config")
+	}
+}
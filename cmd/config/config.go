@@ -0,0 +1,166 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config is the entry point for reading a server config file
+// of any historical version. Each version lives in its own
+// sub-package (v2, v3, ...) modeled after Ignition's config package:
+// every version knows how to Parse itself and how to
+// TranslateFromVPrev from the version immediately before it. Parse,
+// here, reads just enough of the raw document to find the version
+// tag, dispatches to that version's Parse, and then chains
+// TranslateFromVPrev calls up to the latest version.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/minio/minio/cmd/config/report"
+	"github.com/minio/minio/cmd/config/v10"
+	"github.com/minio/minio/cmd/config/v11"
+	"github.com/minio/minio/cmd/config/v2"
+	"github.com/minio/minio/cmd/config/v3"
+	"github.com/minio/minio/cmd/config/v4"
+	"github.com/minio/minio/cmd/config/v5"
+	"github.com/minio/minio/cmd/config/v6"
+	"github.com/minio/minio/cmd/config/v7"
+	"github.com/minio/minio/cmd/config/v8"
+	"github.com/minio/minio/cmd/config/v9"
+)
+
+// Config is an alias for the latest known version, so callers of this
+// package never need to know which version number is current.
+type Config = v11.Config
+
+// versionTag is the only field Parse needs to look at before
+// dispatching to the matching version package.
+type versionTag struct {
+	Version string `json:"version"`
+}
+
+// Parse reads a server config file of any version this server has
+// ever shipped and returns it translated up to the latest version.
+// Fields that were dropped along the way (e.g. the mongoLogger and
+// syslogLogger backends removed in v3) are recorded as warnings in
+// the returned report rather than silently discarded.
+func Parse(raw []byte) (Config, *report.Report, error) {
+	var tag versionTag
+	if err := json.Unmarshal(raw, &tag); err != nil {
+		return Config{}, nil, err
+	}
+
+	rep := report.New()
+	switch tag.Version {
+	case "2":
+		cfg, err := v2.Parse(raw)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		return chainFromV2(cfg, rep), rep, nil
+	case "3":
+		cfg, err := v3.Parse(raw)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		return chainFromV3(cfg, rep), rep, nil
+	case "4":
+		cfg, err := v4.Parse(raw)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		return chainFromV4(cfg, rep), rep, nil
+	case "5":
+		cfg, err := v5.Parse(raw)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		return chainFromV5(cfg, rep), rep, nil
+	case "6":
+		cfg, err := v6.Parse(raw)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		return chainFromV6(cfg, rep), rep, nil
+	case "7":
+		cfg, err := v7.Parse(raw)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		return chainFromV7(cfg, rep), rep, nil
+	case "8":
+		cfg, err := v8.Parse(raw)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		return chainFromV8(cfg, rep), rep, nil
+	case "9":
+		cfg, err := v9.Parse(raw)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		return chainFromV9(cfg, rep), rep, nil
+	case "10":
+		cfg, err := v10.Parse(raw)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		return chainFromV10(cfg, rep), rep, nil
+	case "11":
+		cfg, err := v11.Parse(raw)
+		if err != nil {
+			return Config{}, nil, err
+		}
+		return cfg, rep, nil
+	default:
+		return Config{}, nil, fmt.Errorf("config: unknown or unsupported version %q", tag.Version)
+	}
+}
+
+func chainFromV2(cfg v2.Config, rep *report.Report) Config {
+	return chainFromV3(v3.TranslateFromV2(cfg, rep), rep)
+}
+
+func chainFromV3(cfg v3.Config, rep *report.Report) Config {
+	return chainFromV4(v4.TranslateFromV3(cfg, rep), rep)
+}
+
+func chainFromV4(cfg v4.Config, rep *report.Report) Config {
+	return chainFromV5(v5.TranslateFromV4(cfg, rep), rep)
+}
+
+func chainFromV5(cfg v5.Config, rep *report.Report) Config {
+	return chainFromV6(v6.TranslateFromV5(cfg, rep), rep)
+}
+
+func chainFromV6(cfg v6.Config, rep *report.Report) Config {
+	return chainFromV7(v7.TranslateFromV6(cfg, rep), rep)
+}
+
+func chainFromV7(cfg v7.Config, rep *report.Report) Config {
+	return chainFromV8(v8.TranslateFromV7(cfg, rep), rep)
+}
+
+func chainFromV8(cfg v8.Config, rep *report.Report) Config {
+	return chainFromV9(v9.TranslateFromV8(cfg, rep), rep)
+}
+
+func chainFromV9(cfg v9.Config, rep *report.Report) Config {
+	return chainFromV10(v10.TranslateFromV9(cfg, rep), rep)
+}
+
+func chainFromV10(cfg v10.Config, rep *report.Report) Config {
+	return v11.TranslateFromV10(cfg, rep)
+}
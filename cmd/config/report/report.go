@@ -0,0 +1,57 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package report collects non-fatal diagnostics produced while translating
+// a configuration from one version to the next, e.g. fields that were
+// dropped because the target version no longer supports them.
+package report
+
+import "fmt"
+
+// Report accumulates warning messages gathered while a config is parsed
+// and translated forward to the latest version. A nil *Report is valid
+// and silently discards messages, so translators do not need to nil
+// check before calling Add.
+type Report struct {
+	messages []string
+}
+
+// New returns an empty Report.
+func New() *Report {
+	return &Report{}
+}
+
+// Add formats and records a warning message.
+func (r *Report) Add(format string, args ...interface{}) {
+	if r == nil {
+		return
+	}
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+// Messages returns the warnings collected so far, in the order they
+// were added.
+func (r *Report) Messages() []string {
+	if r == nil {
+		return nil
+	}
+	return r.messages
+}
+
+// HasWarnings returns true if at least one warning was recorded.
+func (r *Report) HasWarnings() bool {
+	return r != nil && len(r.messages) > 0
+}
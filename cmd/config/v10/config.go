@@ -0,0 +1,65 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v10 is the typed representation of the version "10" server
+// config file. v10 adds the Domain setting used for virtual-host
+// style bucket addressing.
+package v10
+
+import (
+	"encoding/json"
+
+	"github.com/minio/minio/cmd/config/report"
+	"github.com/minio/minio/cmd/config/v9"
+)
+
+// Credential, Logger and Notify are unchanged from v9.
+type (
+	Credential = v9.Credential
+	Logger     = v9.Logger
+	Notify     = v9.Notify
+)
+
+// Config is the version "10" server configuration.
+type Config struct {
+	Version    string     `json:"version"`
+	Credential Credential `json:"credentials"`
+	Logger     Logger     `json:"logger"`
+	Notify     Notify     `json:"notify"`
+	Browser    bool       `json:"browser"`
+	Domain     string     `json:"domain"`
+}
+
+// Parse decodes raw JSON into a v10 Config.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// TranslateFromV9 upgrades a v9 Config to v10. Domain has no v9
+// equivalent and defaults to empty, meaning path-style addressing.
+func TranslateFromV9(prev v9.Config, rep *report.Report) Config {
+	return Config{
+		Version:    "10",
+		Credential: prev.Credential,
+		Logger:     prev.Logger,
+		Notify:     prev.Notify,
+		Browser:    prev.Browser,
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v11 is the typed representation of the version "11" server
+// config file, the current version. v11 adds the storage class
+// settings used to pick redundancy for standard and reduced
+// redundancy objects.
+package v11
+
+import (
+	"encoding/json"
+
+	"github.com/minio/minio/cmd/config/report"
+	"github.com/minio/minio/cmd/config/v10"
+)
+
+// Credential, Logger and Notify are unchanged from v10.
+type (
+	Credential = v10.Credential
+	Logger     = v10.Logger
+	Notify     = v10.Notify
+)
+
+// StorageClass controls the erasure coded parity used for standard
+// and reduced redundancy objects.
+type StorageClass struct {
+	Standard string `json:"standard"`
+	RRS      string `json:"rrs"`
+}
+
+// Config is the version "11" server configuration, the latest one
+// understood by this server.
+type Config struct {
+	Version      string       `json:"version"`
+	Credential   Credential   `json:"credentials"`
+	Logger       Logger       `json:"logger"`
+	Notify       Notify       `json:"notify"`
+	Browser      bool         `json:"browser"`
+	Domain       string       `json:"domain"`
+	StorageClass StorageClass `json:"storageclass"`
+	// NamingPolicy selects the bucket/object NamingPolicy this server
+	// enforces: "", "s3-strict" (the default), "s3-relaxed" or
+	// "posix". See cmd.namingPolicyFromName.
+	NamingPolicy string `json:"namingPolicy"`
+}
+
+// Parse decodes raw JSON into a v11 Config.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// TranslateFromV10 upgrades a v10 Config to v11. StorageClass has no
+// v10 equivalent and defaults to the zero value, which callers treat
+// as "use the server's built-in default".
+func TranslateFromV10(prev v10.Config, rep *report.Report) Config {
+	return Config{
+		Version:    "11",
+		Credential: prev.Credential,
+		Logger:     prev.Logger,
+		Notify:     prev.Notify,
+		Browser:    prev.Browser,
+		Domain:     prev.Domain,
+	}
+}
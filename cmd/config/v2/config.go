@@ -0,0 +1,70 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v2 is the typed representation of the version "2" server
+// config file, the oldest version still found in the wild. It only
+// knows how to parse itself; translating forward to v3 lives in the
+// v3 package, which is the one that has to know what v2 looked like.
+package v2
+
+import "encoding/json"
+
+// Credential holds the root access/secret key pair and the region the
+// server was configured for.
+type Credential struct {
+	AccessKey string `json:"accessKeyId"`
+	SecretKey string `json:"secretAccessKey"`
+	Region    string `json:"region"`
+}
+
+// MongoLogger was the audit logger backed by MongoDB. Support for it
+// was dropped in v3.
+type MongoLogger struct {
+	Addr       string `json:"addr"`
+	DB         string `json:"db"`
+	Collection string `json:"collection"`
+}
+
+// SyslogLogger was the audit logger that shipped to a syslog
+// endpoint. Support for it was dropped in v3.
+type SyslogLogger struct {
+	Network string `json:"network"`
+	Addr    string `json:"addr"`
+}
+
+// FileLogger writes audit logs to a local file.
+type FileLogger struct {
+	Filename string `json:"filename"`
+}
+
+// Config is the version "2" server configuration.
+type Config struct {
+	Version      string       `json:"version"`
+	Credential   Credential   `json:"credentials"`
+	MongoLogger  MongoLogger  `json:"mongoLogger"`
+	SyslogLogger SyslogLogger `json:"syslogLogger"`
+	FileLogger   FileLogger   `json:"fileLogger"`
+}
+
+// Parse decodes raw JSON into a v2 Config. Callers are expected to
+// have already checked the "version" field.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
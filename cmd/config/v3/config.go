@@ -0,0 +1,79 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v3 is the typed representation of the version "3" server
+// config file. v3 keeps the v2 Credential (region included) as is
+// and dropped the mongoLogger/syslogLogger backends in favor of a
+// single Logger section.
+package v3
+
+import (
+	"encoding/json"
+
+	"github.com/minio/minio/cmd/config/report"
+	"github.com/minio/minio/cmd/config/v2"
+)
+
+// Credential is unchanged from v2.
+type Credential = v2.Credential
+
+// ConsoleLogger controls whether audit events are also written to
+// stdout.
+type ConsoleLogger struct {
+	Enable bool `json:"enable"`
+}
+
+// Logger groups the audit logging backends supported starting with
+// v3: console and file.
+type Logger struct {
+	Console ConsoleLogger `json:"console"`
+	File    v2.FileLogger `json:"file"`
+}
+
+// Config is the version "3" server configuration.
+type Config struct {
+	Version    string     `json:"version"`
+	Credential Credential `json:"credentials"`
+	Logger     Logger     `json:"logger"`
+}
+
+// Parse decodes raw JSON into a v3 Config.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// TranslateFromV2 upgrades a v2 Config to v3. The mongoLogger and
+// syslogLogger backends have no v3 equivalent, so their presence is
+// recorded in rep rather than silently discarded.
+func TranslateFromV2(prev v2.Config, rep *report.Report) Config {
+	cfg := Config{
+		Version:    "3",
+		Credential: prev.Credential,
+	}
+	cfg.Logger.File = prev.FileLogger
+
+	if (prev.MongoLogger != v2.MongoLogger{}) {
+		rep.Add("v2->v3: dropped mongoLogger config (addr=%q), MongoDB audit logging is no longer supported", prev.MongoLogger.Addr)
+	}
+	if (prev.SyslogLogger != v2.SyslogLogger{}) {
+		rep.Add("v2->v3: dropped syslogLogger config (addr=%q), syslog audit logging is no longer supported", prev.SyslogLogger.Addr)
+	}
+	return cfg
+}
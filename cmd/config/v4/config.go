@@ -0,0 +1,74 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v4 is the typed representation of the version "4" server
+// config file. v4 introduces bucket notifications, starting with the
+// AMQP target.
+package v4
+
+import (
+	"encoding/json"
+
+	"github.com/minio/minio/cmd/config/report"
+	"github.com/minio/minio/cmd/config/v3"
+)
+
+// Credential and Logger are unchanged from v3.
+type (
+	Credential = v3.Credential
+	Logger     = v3.Logger
+)
+
+// AMQPArgs configures a single AMQP notification target.
+type AMQPArgs struct {
+	Enable     bool   `json:"enable"`
+	URL        string `json:"url"`
+	Exchange   string `json:"exchange"`
+	RoutingKey string `json:"routingKey"`
+}
+
+// Notify groups the notification targets supported starting with v4.
+type Notify struct {
+	AMQP map[string]AMQPArgs `json:"amqp"`
+}
+
+// Config is the version "4" server configuration.
+type Config struct {
+	Version    string     `json:"version"`
+	Credential Credential `json:"credentials"`
+	Logger     Logger     `json:"logger"`
+	Notify     Notify     `json:"notify"`
+}
+
+// Parse decodes raw JSON into a v4 Config.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// TranslateFromV3 upgrades a v3 Config to v4. v3 had no notification
+// targets, so Notify starts out empty.
+func TranslateFromV3(prev v3.Config, rep *report.Report) Config {
+	return Config{
+		Version:    "4",
+		Credential: prev.Credential,
+		Logger:     prev.Logger,
+		Notify:     Notify{AMQP: map[string]AMQPArgs{}},
+	}
+}
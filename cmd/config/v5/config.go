@@ -0,0 +1,86 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v5 is the typed representation of the version "5" server
+// config file. v5 adds the Elasticsearch and Redis notification
+// targets.
+package v5
+
+import (
+	"encoding/json"
+
+	"github.com/minio/minio/cmd/config/report"
+	"github.com/minio/minio/cmd/config/v4"
+)
+
+// Credential and Logger are unchanged from v4.
+type (
+	Credential = v4.Credential
+	Logger     = v4.Logger
+)
+
+// ElasticsearchArgs configures a single Elasticsearch notification target.
+type ElasticsearchArgs struct {
+	Enable bool   `json:"enable"`
+	URL    string `json:"url"`
+	Index  string `json:"index"`
+}
+
+// RedisArgs configures a single Redis notification target.
+type RedisArgs struct {
+	Enable   bool   `json:"enable"`
+	Addr     string `json:"address"`
+	Key      string `json:"key"`
+	Password string `json:"password"`
+}
+
+// Notify groups the notification targets supported starting with v5.
+type Notify struct {
+	v4.Notify
+	Elasticsearch map[string]ElasticsearchArgs `json:"elasticsearch"`
+	Redis         map[string]RedisArgs         `json:"redis"`
+}
+
+// Config is the version "5" server configuration.
+type Config struct {
+	Version    string     `json:"version"`
+	Credential Credential `json:"credentials"`
+	Logger     Logger     `json:"logger"`
+	Notify     Notify     `json:"notify"`
+}
+
+// Parse decodes raw JSON into a v5 Config.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// TranslateFromV4 upgrades a v4 Config to v5.
+func TranslateFromV4(prev v4.Config, rep *report.Report) Config {
+	return Config{
+		Version:    "5",
+		Credential: prev.Credential,
+		Logger:     prev.Logger,
+		Notify: Notify{
+			Notify:        prev.Notify,
+			Elasticsearch: map[string]ElasticsearchArgs{},
+			Redis:         map[string]RedisArgs{},
+		},
+	}
+}
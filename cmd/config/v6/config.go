@@ -0,0 +1,84 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v6 is the typed representation of the version "6" server
+// config file. v6 adds the PostgreSQL and Kafka notification targets.
+package v6
+
+import (
+	"encoding/json"
+
+	"github.com/minio/minio/cmd/config/report"
+	"github.com/minio/minio/cmd/config/v5"
+)
+
+// Credential and Logger are unchanged from v5.
+type (
+	Credential = v5.Credential
+	Logger     = v5.Logger
+)
+
+// PostgreSQLArgs configures a single PostgreSQL notification target.
+type PostgreSQLArgs struct {
+	Enable           bool   `json:"enable"`
+	ConnectionString string `json:"connectionString"`
+	Table            string `json:"table"`
+}
+
+// KafkaArgs configures a single Kafka notification target.
+type KafkaArgs struct {
+	Enable  bool     `json:"enable"`
+	Brokers []string `json:"brokers"`
+	Topic   string   `json:"topic"`
+}
+
+// Notify groups the notification targets supported starting with v6.
+type Notify struct {
+	v5.Notify
+	PostgreSQL map[string]PostgreSQLArgs `json:"postgresql"`
+	Kafka      map[string]KafkaArgs      `json:"kafka"`
+}
+
+// Config is the version "6" server configuration.
+type Config struct {
+	Version    string     `json:"version"`
+	Credential Credential `json:"credentials"`
+	Logger     Logger     `json:"logger"`
+	Notify     Notify     `json:"notify"`
+}
+
+// Parse decodes raw JSON into a v6 Config.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// TranslateFromV5 upgrades a v5 Config to v6.
+func TranslateFromV5(prev v5.Config, rep *report.Report) Config {
+	return Config{
+		Version:    "6",
+		Credential: prev.Credential,
+		Logger:     prev.Logger,
+		Notify: Notify{
+			Notify:     prev.Notify,
+			PostgreSQL: map[string]PostgreSQLArgs{},
+			Kafka:      map[string]KafkaArgs{},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v7 is the typed representation of the version "7" server
+// config file. v7 adds the NATS notification target.
+package v7
+
+import (
+	"encoding/json"
+
+	"github.com/minio/minio/cmd/config/report"
+	"github.com/minio/minio/cmd/config/v6"
+)
+
+// Credential and Logger are unchanged from v6.
+type (
+	Credential = v6.Credential
+	Logger     = v6.Logger
+)
+
+// NATSArgs configures a single NATS notification target.
+type NATSArgs struct {
+	Enable  bool   `json:"enable"`
+	Address string `json:"address"`
+	Subject string `json:"subject"`
+}
+
+// Notify groups the notification targets supported starting with v7.
+type Notify struct {
+	v6.Notify
+	NATS map[string]NATSArgs `json:"nats"`
+}
+
+// Config is the version "7" server configuration.
+type Config struct {
+	Version    string     `json:"version"`
+	Credential Credential `json:"credentials"`
+	Logger     Logger     `json:"logger"`
+	Notify     Notify     `json:"notify"`
+}
+
+// Parse decodes raw JSON into a v7 Config.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// TranslateFromV6 upgrades a v6 Config to v7.
+func TranslateFromV6(prev v6.Config, rep *report.Report) Config {
+	return Config{
+		Version:    "7",
+		Credential: prev.Credential,
+		Logger:     prev.Logger,
+		Notify: Notify{
+			Notify: prev.Notify,
+			NATS:   map[string]NATSArgs{},
+		},
+	}
+}
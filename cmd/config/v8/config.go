@@ -0,0 +1,74 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v8 is the typed representation of the version "8" server
+// config file. v8 adds the webhook notification target.
+package v8
+
+import (
+	"encoding/json"
+
+	"github.com/minio/minio/cmd/config/report"
+	"github.com/minio/minio/cmd/config/v7"
+)
+
+// Credential and Logger are unchanged from v7.
+type (
+	Credential = v7.Credential
+	Logger     = v7.Logger
+)
+
+// WebhookArgs configures a single webhook notification target.
+type WebhookArgs struct {
+	Enable   bool   `json:"enable"`
+	Endpoint string `json:"endpoint"`
+}
+
+// Notify groups the notification targets supported starting with v8.
+type Notify struct {
+	v7.Notify
+	Webhook map[string]WebhookArgs `json:"webhook"`
+}
+
+// Config is the version "8" server configuration.
+type Config struct {
+	Version    string     `json:"version"`
+	Credential Credential `json:"credentials"`
+	Logger     Logger     `json:"logger"`
+	Notify     Notify     `json:"notify"`
+}
+
+// Parse decodes raw JSON into a v8 Config.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// TranslateFromV7 upgrades a v7 Config to v8.
+func TranslateFromV7(prev v7.Config, rep *report.Report) Config {
+	return Config{
+		Version:    "8",
+		Credential: prev.Credential,
+		Logger:     prev.Logger,
+		Notify: Notify{
+			Notify:  prev.Notify,
+			Webhook: map[string]WebhookArgs{},
+		},
+	}
+}
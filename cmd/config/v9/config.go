@@ -0,0 +1,80 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v9 is the typed representation of the version "9" server
+// config file. v9 adds the MQTT notification target and a top-level
+// switch for the browser-based object browser.
+package v9
+
+import (
+	"encoding/json"
+
+	"github.com/minio/minio/cmd/config/report"
+	"github.com/minio/minio/cmd/config/v8"
+)
+
+// Credential and Logger are unchanged from v8.
+type (
+	Credential = v8.Credential
+	Logger     = v8.Logger
+)
+
+// MQTTArgs configures a single MQTT notification target.
+type MQTTArgs struct {
+	Enable bool   `json:"enable"`
+	Broker string `json:"broker"`
+	Topic  string `json:"topic"`
+	QoS    byte   `json:"qos"`
+}
+
+// Notify groups the notification targets supported starting with v9.
+type Notify struct {
+	v8.Notify
+	MQTT map[string]MQTTArgs `json:"mqtt"`
+}
+
+// Config is the version "9" server configuration.
+type Config struct {
+	Version    string     `json:"version"`
+	Credential Credential `json:"credentials"`
+	Logger     Logger     `json:"logger"`
+	Notify     Notify     `json:"notify"`
+	Browser    bool       `json:"browser"`
+}
+
+// Parse decodes raw JSON into a v9 Config.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// TranslateFromV8 upgrades a v8 Config to v9. The browser is enabled
+// by default, matching the server's previous hard-coded behavior.
+func TranslateFromV8(prev v8.Config, rep *report.Report) Config {
+	return Config{
+		Version:    "9",
+		Credential: prev.Credential,
+		Logger:     prev.Logger,
+		Notify: Notify{
+			Notify: prev.Notify,
+			MQTT:   map[string]MQTTArgs{},
+		},
+		Browser: true,
+	}
+}
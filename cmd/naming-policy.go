@@ -0,0 +1,277 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// NamingPolicy decides whether a bucket or object name is acceptable
+// to store. Different backends and deployments have different
+// constraints - a gateway fronting a real S3 region cares about DNS
+// compatibility, a POSIX backend cares about what the local
+// filesystem can represent - so the policy in effect is selected once
+// at startup rather than hard-coded.
+type NamingPolicy interface {
+	// ValidateBucket returns nil if bucket is an acceptable bucket
+	// name under this policy, otherwise one of the Err* values
+	// defined below.
+	ValidateBucket(bucket string) error
+	// ValidateObject returns nil if object is an acceptable object
+	// name under this policy, otherwise one of the Err* values
+	// defined below.
+	ValidateObject(object string) error
+}
+
+// Typed naming errors. Handlers map these to the matching S3 error
+// code instead of a generic "invalid bucket name" response.
+var (
+	ErrBucketNameTooShort = fmt.Errorf("bucket name must be at least 3 characters long")
+	ErrBucketNameTooLong  = fmt.Errorf("bucket name exceeds the maximum length allowed by this naming policy")
+	ErrBucketNameIPFormat = fmt.Errorf("bucket name must not be formatted as an IP address")
+	ErrBucketNameDNS      = fmt.Errorf("bucket name must be DNS compliant: lowercase letters, numbers, hyphens and dots only, must start and end with a letter or number")
+	ErrBucketNameEmpty    = fmt.Errorf("bucket name cannot be empty")
+	ErrBucketNameReserved = fmt.Errorf("bucket name is reserved on this backend")
+	ErrBucketNameBadChar  = fmt.Errorf("bucket name contains a character that cannot be represented on this backend")
+	ErrObjectNameEmpty    = fmt.Errorf("object name cannot be empty")
+	ErrObjectNameTooLong  = fmt.Errorf("object name must be no more than 1024 characters long")
+	ErrObjectNameBadStart = fmt.Errorf("object name must not start or end with a slash")
+	ErrObjectNameBadChar  = fmt.Errorf("object name contains a character that cannot be represented on this backend")
+	ErrObjectNameReserved = fmt.Errorf("object name component is reserved on this backend")
+)
+
+// ipAddressRegex matches strings that look like an IPv4 address,
+// which S3 disallows as a bucket name since it would be ambiguous
+// with virtual-host style addressing.
+var ipAddressRegex = regexp.MustCompile(`^(\d{1,3}\.){3}\d{1,3}$`)
+
+// validBucketDNSRegex matches the strict, DNS-compatible bucket name
+// grammar: lowercase letters, digits, hyphens and dots, starting and
+// ending with a letter or digit.
+var validBucketDNSRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*[a-z0-9]$`)
+
+// validBucketRelaxedRegex additionally allows uppercase letters and
+// underscores, matching the legacy naming rules real AWS still
+// accepts in us-east-1 for buckets created before the DNS-compliant
+// rules were enforced everywhere.
+var validBucketRelaxedRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*[a-zA-Z0-9]$`)
+
+// s3InvalidObjectName rejects empty names, names over 1024 bytes,
+// names that start or end with a slash, names containing a backslash
+// and names that are not valid UTF-8; everything else, including
+// control characters and most Unicode, is allowed.
+func s3InvalidObjectName(object string) bool {
+	if len(object) == 0 || len(object) > 1024 {
+		return true
+	}
+	if strings.HasPrefix(object, "/") || strings.HasSuffix(object, "/") {
+		return true
+	}
+	return strings.ContainsAny(object, `\`) || !utf8.ValidString(object)
+}
+
+// S3StrictPolicy implements today's default behavior: DNS-compliant
+// bucket names and the existing object name blacklist. This is what
+// IsValidBucketName and IsValidObjectName enforced before
+// NamingPolicy existed, and it remains the default so existing
+// deployments see no behavior change.
+type S3StrictPolicy struct{}
+
+// ValidateBucket implements NamingPolicy.
+func (S3StrictPolicy) ValidateBucket(bucket string) error {
+	if len(bucket) == 0 {
+		return ErrBucketNameEmpty
+	}
+	if len(bucket) < 3 {
+		return ErrBucketNameTooShort
+	}
+	if len(bucket) > 63 {
+		return ErrBucketNameTooLong
+	}
+	if ipAddressRegex.MatchString(bucket) {
+		return ErrBucketNameIPFormat
+	}
+	if strings.Contains(bucket, "..") || strings.Contains(bucket, ".-") || strings.Contains(bucket, "-.") {
+		return ErrBucketNameDNS
+	}
+	if !validBucketDNSRegex.MatchString(bucket) {
+		return ErrBucketNameDNS
+	}
+	return nil
+}
+
+// ValidateObject implements NamingPolicy.
+func (S3StrictPolicy) ValidateObject(object string) error {
+	if s3InvalidObjectName(object) {
+		if len(object) == 0 {
+			return ErrObjectNameEmpty
+		}
+		if len(object) > 1024 {
+			return ErrObjectNameTooLong
+		}
+		if strings.HasPrefix(object, "/") || strings.HasSuffix(object, "/") {
+			return ErrObjectNameBadStart
+		}
+		return ErrObjectNameBadChar
+	}
+	return nil
+}
+
+// S3RelaxedPolicy accepts the wider set of bucket names real AWS
+// still honors in us-east-1 for buckets created before DNS-compliant
+// naming was enforced everywhere: mixed case and underscores are
+// allowed alongside the strict grammar. Object naming is unchanged
+// from S3StrictPolicy.
+type S3RelaxedPolicy struct{}
+
+// ValidateBucket implements NamingPolicy.
+func (S3RelaxedPolicy) ValidateBucket(bucket string) error {
+	if len(bucket) == 0 {
+		return ErrBucketNameEmpty
+	}
+	if len(bucket) < 3 {
+		return ErrBucketNameTooShort
+	}
+	if len(bucket) > 255 {
+		return ErrBucketNameTooLong
+	}
+	if ipAddressRegex.MatchString(bucket) {
+		return ErrBucketNameIPFormat
+	}
+	if strings.Contains(bucket, "..") || strings.Contains(bucket, ".-") || strings.Contains(bucket, "-.") {
+		return ErrBucketNameDNS
+	}
+	if !validBucketRelaxedRegex.MatchString(bucket) {
+		return ErrBucketNameDNS
+	}
+	return nil
+}
+
+// ValidateObject implements NamingPolicy.
+func (S3RelaxedPolicy) ValidateObject(object string) error {
+	return S3StrictPolicy{}.ValidateObject(object)
+}
+
+// windowsReservedNames lists the device names Windows reserves
+// regardless of extension (CON, CON.txt, con/ are all invalid).
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true,
+	"COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true,
+	"LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// POSIXPolicy rejects names the underlying filesystem cannot
+// represent as a path component: control characters, NUL, names that
+// are just "." or "..", trailing dots or spaces (both silently
+// stripped by Windows), and the Windows reserved device names, since
+// a FS backend has to work whether the volume it's given is Linux,
+// macOS or Windows.
+type POSIXPolicy struct{}
+
+// ValidateBucket implements NamingPolicy.
+func (p POSIXPolicy) ValidateBucket(bucket string) error {
+	if len(bucket) == 0 {
+		return ErrBucketNameEmpty
+	}
+	if len(bucket) > 255 {
+		return ErrBucketNameTooLong
+	}
+	return p.validatePathComponent(bucket, ErrBucketNameBadChar, ErrBucketNameReserved)
+}
+
+// ValidateObject implements NamingPolicy.
+func (p POSIXPolicy) ValidateObject(object string) error {
+	if len(object) == 0 {
+		return ErrObjectNameEmpty
+	}
+	if len(object) > 1024 {
+		return ErrObjectNameTooLong
+	}
+	if strings.HasPrefix(object, "/") || strings.HasSuffix(object, "/") {
+		return ErrObjectNameBadStart
+	}
+	for _, part := range strings.Split(object, "/") {
+		if err := p.validatePathComponent(part, ErrObjectNameBadChar, ErrObjectNameReserved); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePathComponent checks a single path component (a bucket name,
+// or one "/"-separated piece of an object name) against what the
+// underlying filesystem can represent. badCharErr and reservedErr let
+// ValidateBucket and ValidateObject each report failures using their
+// own typed sentinel instead of sharing one across both name kinds.
+func (POSIXPolicy) validatePathComponent(name string, badCharErr, reservedErr error) error {
+	if name == "." || name == ".." {
+		return badCharErr
+	}
+	if strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+		return badCharErr
+	}
+	if !utf8.ValidString(name) {
+		return badCharErr
+	}
+	for _, r := range name {
+		if r < 0x20 || r == '<' || r == '>' || r == ':' || r == '"' ||
+			r == '/' || r == '\\' || r == '|' || r == '?' || r == '*' {
+			return badCharErr
+		}
+	}
+
+	base := name
+	if i := strings.IndexByte(base, '.'); i >= 0 {
+		base = base[:i]
+	}
+	if windowsReservedNames[strings.ToUpper(base)] {
+		return reservedErr
+	}
+	return nil
+}
+
+// globalNamingPolicy is the policy selected at startup, S3StrictPolicy
+// by default so the server keeps today's behavior unless an operator
+// opts into a different one via serverConfig.
+var globalNamingPolicy NamingPolicy = S3StrictPolicy{}
+
+// setNamingPolicy wires the policy chosen from serverConfig into the
+// validators used by IsValidBucketName/IsValidObjectName. It is
+// called once, while the server config is loaded at startup.
+func setNamingPolicy(policy NamingPolicy) {
+	globalNamingPolicy = policy
+}
+
+// namingPolicyFromName maps the config.Config.NamingPolicy value to
+// the NamingPolicy it names, defaulting to S3StrictPolicy for an
+// empty or unrecognized value so a typo in the config falls back to
+// today's behavior instead of refusing to start.
+func namingPolicyFromName(name string) NamingPolicy {
+	switch name {
+	case "s3-relaxed":
+		return S3RelaxedPolicy{}
+	case "posix":
+		return POSIXPolicy{}
+	default:
+		return S3StrictPolicy{}
+	}
+}
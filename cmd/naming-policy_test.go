@@ -0,0 +1,120 @@
+/*
+ * Minio Cloud Storage, (C) 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+// Tests that POSIXPolicy rejects the Windows reserved device names,
+// with or without an extension, case-insensitively, since a FS
+// backend has to work whether the volume it's given is Linux, macOS
+// or Windows.
+func TestPOSIXPolicyReservedNames(t *testing.T) {
+	testCases := []struct {
+		name       string
+		shouldPass bool
+	}{
+		{"CON", false},
+		{"con", false},
+		{"con.txt", false},
+		{"NUL", false},
+		{"COM1", false},
+		{"LPT9", false},
+		{"CONSOLE", true}, // not an exact reserved name
+		{"CONTROL.txt", true},
+		{"readme.txt", true},
+	}
+
+	p := POSIXPolicy{}
+	for i, tc := range testCases {
+		err := p.ValidateObject(tc.name)
+		if tc.shouldPass && err != nil {
+			t.Errorf("test case %d: expected %q to be valid, got %v", i+1, tc.name, err)
+		}
+		if !tc.shouldPass && err == nil {
+			t.Errorf("test case %d: expected %q to be rejected as reserved", i+1, tc.name)
+		}
+	}
+}
+
+// Tests that POSIXPolicy rejects control characters and the handful
+// of characters Windows cannot store in a path component, while the
+// S3 policies allow them since real S3 object keys may contain
+// arbitrary bytes.
+func TestPOSIXPolicyBadCharacters(t *testing.T) {
+	testCases := []string{
+		"contains-\x01-control",
+		"contains-<-bracket",
+		"contains-:-colon",
+		"contains-?-question",
+	}
+
+	p := POSIXPolicy{}
+	for _, name := range testCases {
+		if err := p.ValidateObject(name); err == nil {
+			t.Errorf("expected %q to be rejected by POSIXPolicy", name)
+		}
+		if err := (S3StrictPolicy{}).ValidateObject(name); err != nil {
+			t.Errorf("expected %q to be accepted by S3StrictPolicy, got %v", name, err)
+		}
+	}
+}
+
+// Tests that every policy returns one of the typed naming errors
+// rather than an opaque error, so handlers can map it to the correct
+// S3 error code.
+func TestNamingPolicyTypedErrors(t *testing.T) {
+	if err := (S3StrictPolicy{}).ValidateBucket(""); err != ErrBucketNameEmpty {
+		t.Fatalf("expected ErrBucketNameEmpty, got %v", err)
+	}
+	if err := (S3StrictPolicy{}).ValidateBucket("ab"); err != ErrBucketNameTooShort {
+		t.Fatalf("expected ErrBucketNameTooShort, got %v", err)
+	}
+	if err := (S3StrictPolicy{}).ValidateBucket("192.168.1.1"); err != ErrBucketNameIPFormat {
+		t.Fatalf("expected ErrBucketNameIPFormat, got %v", err)
+	}
+	if err := (S3StrictPolicy{}).ValidateBucket("THIS-IS-UPPERCASE"); err != ErrBucketNameDNS {
+		t.Fatalf("expected ErrBucketNameDNS, got %v", err)
+	}
+	if err := (S3StrictPolicy{}).ValidateObject(""); err != ErrObjectNameEmpty {
+		t.Fatalf("expected ErrObjectNameEmpty, got %v", err)
+	}
+	if err := (POSIXPolicy{}).ValidateObject("CON"); err != ErrObjectNameReserved {
+		t.Fatalf("expected ErrObjectNameReserved, got %v", err)
+	}
+	if err := (POSIXPolicy{}).ValidateBucket("con"); err != ErrBucketNameReserved {
+		t.Fatalf("expected ErrBucketNameReserved for a reserved bucket name, got %v", err)
+	}
+}
+
+// Tests that namingPolicyFromName maps config names to the expected
+// policy, defaulting to S3StrictPolicy for anything unrecognized.
+func TestNamingPolicyFromName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected NamingPolicy
+	}{
+		{"", S3StrictPolicy{}},
+		{"s3-relaxed", S3RelaxedPolicy{}},
+		{"posix", POSIXPolicy{}},
+		{"bogus", S3StrictPolicy{}},
+	}
+	for _, tc := range testCases {
+		if got := namingPolicyFromName(tc.name); got != tc.expected {
+			t.Errorf("namingPolicyFromName(%q) = %v, want %v", tc.name, got, tc.expected)
+		}
+	}
+}
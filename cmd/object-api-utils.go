@@ -0,0 +1,99 @@
+/*
+ * Minio Cloud Storage, (C) 2016, 2017 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// IsValidBucketName verifies that a bucket name meets the naming
+// policy selected for this server (see setNamingPolicy). It returns a
+// bare bool; callers that need the reason should call ValidateBucket
+// directly on a NamingPolicy, which returns a typed error.
+func IsValidBucketName(bucket string) bool {
+	return globalNamingPolicy.ValidateBucket(bucket) == nil
+}
+
+// IsValidObjectName verifies that an object name meets the naming
+// policy selected for this server (see setNamingPolicy).
+func IsValidObjectName(object string) bool {
+	return globalNamingPolicy.ValidateObject(object) == nil
+}
+
+// rangeReader reads from the underlying Reader and fails if the
+// stream turns out to hold fewer than Min or more than Max bytes. It
+// is used to enforce request body size limits without buffering the
+// whole body in memory. A negative Max means no upper bound.
+type rangeReader struct {
+	io.Reader
+	Min int64
+	Max int64
+
+	read int64
+}
+
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if r.Max >= 0 {
+		remaining := r.Max - r.read
+		if remaining <= 0 {
+			// We've already delivered Max bytes; the only thing left
+			// to determine is whether the stream actually ends here.
+			var extra [1]byte
+			if n, _ := r.Reader.Read(extra[:]); n > 0 {
+				return 0, errDataTooLarge
+			}
+			return 0, io.EOF
+		}
+		if int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+	}
+
+	n, err := r.Reader.Read(p)
+	r.read += int64(n)
+
+	if err == io.EOF && r.read < r.Min {
+		return n, errDataTooSmall
+	}
+	return n, err
+}
+
+// completePart represents a single <Part> entry of a
+// CompleteMultipartUpload request body.
+type completePart struct {
+	PartNumber int
+	ETag       string
+}
+
+// getCompleteMultipartMD5 computes the S3-style ETag for a completed
+// multipart upload: the MD5 of the concatenated binary MD5s of every
+// part, followed by "-" and the part count.
+func getCompleteMultipartMD5(parts []completePart) (string, error) {
+	var finalMD5Bytes []byte
+	for _, part := range parts {
+		md5Bytes, err := hex.DecodeString(part.ETag)
+		if err != nil {
+			return "", err
+		}
+		finalMD5Bytes = append(finalMD5Bytes, md5Bytes...)
+	}
+	sum := md5.Sum(finalMD5Bytes)
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:]), len(parts)), nil
+}
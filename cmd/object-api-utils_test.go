@@ -22,96 +22,138 @@ import (
 	"testing"
 )
 
-// Tests validate bucket name.
+// Tests validate bucket name, one expected outcome per NamingPolicy
+// since S3StrictPolicy, S3RelaxedPolicy and POSIXPolicy disagree on a
+// number of these (e.g. POSIXPolicy has no DNS-compliance rule, while
+// S3RelaxedPolicy allows the legacy mixed-case names real AWS still
+// honors in us-east-1).
 func TestIsValidBucketName(t *testing.T) {
+	// shouldPass is indexed [strict, relaxed, posix], matching policies below.
 	testCases := []struct {
 		bucketName string
-		shouldPass bool
+		shouldPass [3]bool
 	}{
-		// cases which should pass the test.
-		// passing in valid bucket names.
-		{"lol", true},
-		{"1-this-is-valid", true},
-		{"1-this-too-is-valid-1", true},
-		{"this.works.too.1", true},
-		{"1234567", true},
-		{"123", true},
-		{"s3-eu-west-1.amazonaws.com", true},
-		{"ideas-are-more-powerful-than-guns", true},
-		{"testbucket", true},
-		{"1bucket", true},
-		{"bucket1", true},
-		// cases for which test should fail.
-		// passing invalid bucket names.
-		{"------", false},
-		{"my..bucket", false},
-		{"192.168.1.1", false},
-		{"$this-is-not-valid-too", false},
-		{"contains-$-dollar", false},
-		{"contains-^-carret", false},
-		{"contains-$-dollar", false},
-		{"contains-$-dollar", false},
-		{"......", false},
-		{"", false},
-		{"a", false},
-		{"ab", false},
-		{".starts-with-a-dot", false},
-		{"ends-with-a-dot.", false},
-		{"ends-with-a-dash-", false},
-		{"-starts-with-a-dash", false},
-		{"THIS-BEINGS-WITH-UPPERCASe", false},
-		{"tHIS-ENDS-WITH-UPPERCASE", false},
-		{"ThisBeginsAndEndsWithUpperCase", false},
-		{"una ñina", false},
-		{"lalalallalallalalalallalallalala-theString-size-is-greater-than-64", false},
+		// cases which should pass under every policy.
+		{"lol", [3]bool{true, true, true}},
+		{"1-this-is-valid", [3]bool{true, true, true}},
+		{"1-this-too-is-valid-1", [3]bool{true, true, true}},
+		{"this.works.too.1", [3]bool{true, true, true}},
+		{"1234567", [3]bool{true, true, true}},
+		{"123", [3]bool{true, true, true}},
+		{"s3-eu-west-1.amazonaws.com", [3]bool{true, true, true}},
+		{"ideas-are-more-powerful-than-guns", [3]bool{true, true, true}},
+		{"testbucket", [3]bool{true, true, true}},
+		{"1bucket", [3]bool{true, true, true}},
+		{"bucket1", [3]bool{true, true, true}},
+		// names only a DNS-style policy rejects, but a filesystem is fine with.
+		{"------", [3]bool{false, false, true}},
+		{"my..bucket", [3]bool{false, false, true}},
+		{"192.168.1.1", [3]bool{false, false, true}},
+		{"$this-is-not-valid-too", [3]bool{false, false, true}},
+		{"contains-$-dollar", [3]bool{false, false, true}},
+		{"contains-^-carret", [3]bool{false, false, true}},
+		{"contains-$-dollar", [3]bool{false, false, true}},
+		{"contains-$-dollar", [3]bool{false, false, true}},
+		{"a", [3]bool{false, false, true}},
+		{"ab", [3]bool{false, false, true}},
+		{".starts-with-a-dot", [3]bool{false, false, true}},
+		{"ends-with-a-dash-", [3]bool{false, false, true}},
+		{"-starts-with-a-dash", [3]bool{false, false, true}},
+		{"una ñina", [3]bool{false, false, true}},
+		{"lalalallalallalalalallalallalala-theString-size-is-greater-than-64", [3]bool{false, true, true}},
+		// mixed-case names S3RelaxedPolicy allows but S3StrictPolicy does not.
+		{"THIS-BEINGS-WITH-UPPERCASe", [3]bool{false, true, true}},
+		{"tHIS-ENDS-WITH-UPPERCASE", [3]bool{false, true, true}},
+		{"ThisBeginsAndEndsWithUpperCase", [3]bool{false, true, true}},
+		// names every policy rejects.
+		{"......", [3]bool{false, false, false}},
+		{"", [3]bool{false, false, false}},
+		{"ends-with-a-dot.", [3]bool{false, false, false}},
 	}
 
-	for i, testCase := range testCases {
-		isValidBucketName := IsValidBucketName(testCase.bucketName)
-		if testCase.shouldPass && !isValidBucketName {
-			t.Errorf("Test case %d: Expected \"%s\" to be a valid bucket name", i+1, testCase.bucketName)
-		}
-		if !testCase.shouldPass && isValidBucketName {
-			t.Errorf("Test case %d: Expected bucket name \"%s\" to be invalid", i+1, testCase.bucketName)
+	policies := []struct {
+		name   string
+		policy NamingPolicy
+	}{
+		{"S3StrictPolicy", S3StrictPolicy{}},
+		{"S3RelaxedPolicy", S3RelaxedPolicy{}},
+		{"POSIXPolicy", POSIXPolicy{}},
+	}
+
+	for p, policy := range policies {
+		for i, testCase := range testCases {
+			shouldPass := testCase.shouldPass[p]
+			err := policy.policy.ValidateBucket(testCase.bucketName)
+			if shouldPass && err != nil {
+				t.Errorf("%s, test case %d: expected %q to be a valid bucket name, got %v", policy.name, i+1, testCase.bucketName, err)
+			}
+			if !shouldPass && err == nil {
+				t.Errorf("%s, test case %d: expected bucket name %q to be invalid", policy.name, i+1, testCase.bucketName)
+			}
 		}
 	}
 }
 
-// Tests for validate object name.
+// Tests IsValidBucketName, the package-level helper handlers call,
+// delegates to the policy selected via setNamingPolicy (S3StrictPolicy
+// by default).
+func TestIsValidBucketNameDefaultPolicy(t *testing.T) {
+	if !IsValidBucketName("testbucket") {
+		t.Fatal("Expected \"testbucket\" to be a valid bucket name under the default policy")
+	}
+	if IsValidBucketName("THIS-BEINGS-WITH-UPPERCASe") {
+		t.Fatal("Expected uppercase bucket name to be invalid under the default (strict) policy")
+	}
+}
+
+// Tests for validate object name, one expected outcome per
+// NamingPolicy since POSIXPolicy rejects a handful of characters that
+// are perfectly valid S3 object name characters (*, |, ", <, >) but
+// cannot be represented in a path component on every filesystem.
 func TestIsValidObjectName(t *testing.T) {
+	// shouldPass is indexed [strict, relaxed, posix], matching policies below.
 	testCases := []struct {
 		objectName string
-		shouldPass bool
+		shouldPass [3]bool
 	}{
-		// cases which should pass the test.
-		// passing in valid object name.
-		{"object", true},
-		{"The Shining Script <v1>.pdf", true},
-		{"Cost Benefit Analysis (2009-2010).pptx", true},
-		{"117Gn8rfHL2ACARPAhaFd0AGzic9pUbIA/5OCn5A", true},
-		{"SHØRT", true},
-		{"f*le", true},
-		{"contains-^-carret", true},
-		{"contains-|-pipe", true},
-		{"contains-\"-quote", true},
-		{"contains-`-tick", true},
-		{"There are far too many object names, and far too few bucket names!", true},
-		// cases for which test should fail.
-		// passing invalid object names.
-		{"", false},
-		{"a/b/c/", false},
-		{"/a/b/c", false},
-		{"contains-\\-backslash", false},
-		{string([]byte{0xff, 0xfe, 0xfd}), false},
+		{"object", [3]bool{true, true, true}},
+		{"The Shining Script <v1>.pdf", [3]bool{true, true, false}},
+		{"Cost Benefit Analysis (2009-2010).pptx", [3]bool{true, true, true}},
+		{"117Gn8rfHL2ACARPAhaFd0AGzic9pUbIA/5OCn5A", [3]bool{true, true, true}},
+		{"SHØRT", [3]bool{true, true, true}},
+		{"f*le", [3]bool{true, true, false}},
+		{"contains-^-carret", [3]bool{true, true, true}},
+		{"contains-|-pipe", [3]bool{true, true, false}},
+		{"contains-\"-quote", [3]bool{true, true, false}},
+		{"contains-`-tick", [3]bool{true, true, true}},
+		{"There are far too many object names, and far too few bucket names!", [3]bool{true, true, true}},
+		// cases every policy rejects.
+		{"", [3]bool{false, false, false}},
+		{"a/b/c/", [3]bool{false, false, false}},
+		{"/a/b/c", [3]bool{false, false, false}},
+		{"contains-\\-backslash", [3]bool{false, false, false}},
+		{string([]byte{0xff, 0xfe, 0xfd}), [3]bool{false, false, false}},
 	}
 
-	for i, testCase := range testCases {
-		isValidObjectName := IsValidObjectName(testCase.objectName)
-		if testCase.shouldPass && !isValidObjectName {
-			t.Errorf("Test case %d: Expected \"%s\" to be a valid object name", i+1, testCase.objectName)
-		}
-		if !testCase.shouldPass && isValidObjectName {
-			t.Errorf("Test case %d: Expected object name \"%s\" to be invalid", i+1, testCase.objectName)
+	policies := []struct {
+		name   string
+		policy NamingPolicy
+	}{
+		{"S3StrictPolicy", S3StrictPolicy{}},
+		{"S3RelaxedPolicy", S3RelaxedPolicy{}},
+		{"POSIXPolicy", POSIXPolicy{}},
+	}
+
+	for p, policy := range policies {
+		for i, testCase := range testCases {
+			shouldPass := testCase.shouldPass[p]
+			err := policy.policy.ValidateObject(testCase.objectName)
+			if shouldPass && err != nil {
+				t.Errorf("%s, test case %d: expected %q to be a valid object name, got %v", policy.name, i+1, testCase.objectName, err)
+			}
+			if !shouldPass && err == nil {
+				t.Errorf("%s, test case %d: expected object name %q to be invalid", policy.name, i+1, testCase.objectName)
+			}
 		}
 	}
 }